@@ -1,9 +1,15 @@
 package main
 
-// Tetris pieces can have four rotations and there are seven unique shapes I, O, T, S, Z, J, and L total of 28 entries
-// the shapes are represented as a 4x4 grid of bits
-// array contains 30 entries: it includes the 7 shapes each rotated in 4 ways,
-// with 2 duplicates for the "I" shape and 1 duplicate for the "O" shape, for a total of 30
+import (
+	"time"
+
+	"github.com/gdamore/tcell"
+)
+
+// Tetris pieces can have four rotations and there are seven unique shapes I, O, T, S, Z, J, and L,
+// for a total of 28 entries. The shapes are represented as a 4x4 grid of bits.
+// shapes is indexed as shapes[shape][pos], where shape is 0..6 (I, O, T, S, Z, J, L in that order)
+// and pos is the rotation state 0..3.
 var shapes = [][]int{
 	// I
 	{0x0F00, 0x2222, 0x0F00, 0x2222},
@@ -21,14 +27,56 @@ var shapes = [][]int{
 	{0x4460, 0x0E80, 0xC440, 0x2E00},
 }
 
+// pieceNames holds the display letter for each entry in shapes, in the same order.
+var pieceNames = []string{"I", "O", "T", "S", "Z", "J", "L"}
+
+// Indexes into shapes, pieceNames, and pieceColors for the pieces that need
+// to be singled out by the wall-kick rules in rotate.
+const (
+	shapeI = iota
+	shapeO
+	shapeT
+	shapeS
+	shapeZ
+	shapeJ
+	shapeL
+)
+
+// SRS rotation states, indexed by Shape.pos: spawn, right (clockwise once),
+// 180, and left (counter-clockwise once, i.e. clockwise three times).
+const (
+	rotSpawn = iota
+	rotR
+	rot2
+	rotL
+)
+
+// pieceColors holds the display color for each entry in shapes, in the same order.
+var pieceColors = []tcell.Color{
+	tcell.ColorDarkCyan, // I
+	tcell.ColorYellow,   // O
+	tcell.ColorPurple,   // T
+	tcell.ColorGreen,    // S
+	tcell.ColorRed,      // Z
+	tcell.ColorBlue,     // J
+	tcell.ColorOrange,   // L
+}
+
 var menu = []string{
 	"Tetris game",
 	"",
 	"left   Left",
 	"right  Right",
 	"up     Rotate",
-	"down   Down",
+	"down   Soft drop",
+	"space  Hard drop",
+	"c      Hold",
+	"p      Pause",
 	"esc,q  Exit",
+	"",
+	"Level: %d",
+	"Lines: %d",
+	"Score: %d",
 }
 
 const (
@@ -36,10 +84,28 @@ const (
 	gameGridHeight = 20
 )
 
+// previewSize is how many upcoming pieces are kept queued for display.
+const previewSize = 5
+
+// noHeldPiece marks an empty hold slot.
+const noHeldPiece = -1
+
+// lockDelay is how long a piece rests on the stack, without the player
+// sliding or spinning it, before it is fixed in place.
+const lockDelay = 500 * time.Millisecond
+
+// renderInterval is the fixed cadence of the render loop, independent of gravity.
+const renderInterval = time.Second / 30
+
+// fadeFrames is how many render frames a cleared line flashes before it collapses.
+const fadeFrames = 30
+
 type ShapeState int
 
 const (
 	EmptyState ShapeState = iota
 	FallingState
 	FixedState
+	GhostState
+	FadingState
 )
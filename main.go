@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"math/rand"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -24,10 +26,36 @@ type Game struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	lock   sync.Mutex
+
+	bag      []int // shuffled shapes not yet dealt into queue
+	queue    []int // upcoming shapes, dealt from bag
+	held     int   // shape held via hold(), or noHeldPiece
+	holdUsed bool  // whether hold() has already been used for the current piece
+
+	score int
+	lines int
+	level int
+
+	scores       []ScoreEntry // persisted high-score table
+	gameOver     bool
+	awaitingName bool   // true while prompting for a qualifying high-score name
+	nameEntry    string // name being typed on the game-over screen
+
+	paused         bool
+	lockDelayStart time.Time // zero while the piece isn't resting on the stack
+
+	fadingRows []int // board rows currently flashing before they collapse
+	fadeFrame  int   // render frames elapsed since the current fade started
+
+	lastKick Offset // wall-kick offset used by the piece's last successful rotation
+
+	recorder  *replayRecorder // non-nil while this game's input is being recorded to a replay log
+	tickCount uint64          // gravity steps run since the game started, not counting paused ticks; timestamps replay events
 }
 
 // Shape represents a game piece's shape, its rotation state, and current
-// state whether it's falling or fixed
+// state whether it's falling or fixed. pos is the SRS rotation state
+// (rotSpawn/rotR/rot2/rotL) and indexes into shapes[shape].
 type Shape struct {
 	shape int
 	pos   int
@@ -46,24 +74,66 @@ type Piece struct {
 	PreviousShape Shape
 }
 
-// Board represents the game board which is a 2D array of ShapeState.
-type Board [gameGridHeight][gameGridWidth]ShapeState
+// Cell is one square of the board: its state (empty, falling, fixed, ghost
+// outline, or fading out) and, for an occupied cell, the color of the piece
+// that placed it.
+type Cell struct {
+	state ShapeState
+	color tcell.Color
+}
+
+// Board represents the game board which is a 2D array of Cell.
+type Board [gameGridHeight][gameGridWidth]Cell
 
 // newGame initializes a new game with a given screen, context and cancellation function.
 // It creates a new game piece and returns a pointer to the newly created game.
 func newGame(screen tcell.Screen, ctx context.Context, cancel context.CancelFunc) *Game {
+	scores, err := loadScores()
+	if err != nil {
+		log.Printf("failed to load high scores: %v", err)
+	}
+
 	g := &Game{
 		screen: screen,
 		ctx:    ctx,
 		cancel: cancel,
+		held:   noHeldPiece,
+		level:  1,
+		scores: scores,
 	}
-	g.newPiece()
+	g.spawnFromQueue()
 	return g
 }
 
-// newPiece creates a new game piece and sets it as the current piece of the game
-func (g *Game) newPiece() {
-	shape, pos := rand.Intn(1), rand.Intn(4)
+// newShuffledBag returns the seven tetromino shapes in a random order, following
+// the standard "7-bag" randomizer: every shape appears exactly once before any
+// repeats, which guarantees a fair distribution and prevents long droughts.
+func newShuffledBag() []int {
+	bag := make([]int, len(shapes))
+	for i := range bag {
+		bag[i] = i
+	}
+	rand.Shuffle(len(bag), func(i, j int) {
+		bag[i], bag[j] = bag[j], bag[i]
+	})
+	return bag
+}
+
+// refillQueue tops the upcoming-piece queue up to previewSize, dealing from the
+// bag and reshuffling a fresh bag whenever it runs empty.
+func (g *Game) refillQueue() {
+	for len(g.queue) < previewSize {
+		if len(g.bag) == 0 {
+			g.bag = newShuffledBag()
+		}
+		g.queue = append(g.queue, g.bag[0])
+		g.bag = g.bag[1:]
+	}
+}
+
+// setPiece makes shape the current falling piece, spawning it at the top of the board.
+func (g *Game) setPiece(shape int) {
+	pos := 0
 	x, y := -findFirstNonEmptyColumn(shapes[shape][pos]), -getTopOffset(shapes[shape][pos])
 	g.piece = &Piece{
 		Shape: Shape{
@@ -82,24 +152,77 @@ func (g *Game) newPiece() {
 			pos:   pos,
 		},
 	}
+	g.lockDelayStart = time.Time{}
+}
+
+// spawnFromQueue pops the next shape off the queue and sets it as the current
+// piece of the game, refilling the queue from the bag and resetting the hold lock.
+func (g *Game) spawnFromQueue() {
+	g.refillQueue()
+	shape := g.queue[0]
+	g.queue = g.queue[1:]
+	g.refillQueue()
+	g.setPiece(shape)
+	g.holdUsed = false
+}
+
+// hold swaps the current piece into the hold slot, either pulling out a
+// previously held piece or dealing a fresh one from the queue. It can only be
+// used once per spawned piece, until the piece locks.
+func (g *Game) hold() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.holdUsed {
+		return
+	}
+
+	current := shapes[g.piece.Shape.shape][g.piece.Shape.pos]
+	g.fixPiece(current, g.piece.Position.x, g.piece.Position.y, EmptyState, tcell.ColorDefault)
+
+	currentShape := g.piece.Shape.shape
+	if g.held == noHeldPiece {
+		g.held = currentShape
+		g.spawnFromQueue()
+	} else {
+		heldShape := g.held
+		g.held = currentShape
+		g.setPiece(heldShape)
+	}
+	g.holdUsed = true
+	g.tick()
 }
 
 // handleKey handles key events for the game. It enables control of the game
 // piece's movements using keyboard
 func (g *Game) handleKey(ev *tcell.EventKey) {
-	// control the piece movements using keyboard
-	switch ev.Key() {
-	case tcell.KeyEsc, tcell.KeyCtrlC:
+	if ev.Key() == tcell.KeyEsc || ev.Key() == tcell.KeyCtrlC {
 		g.cancel()
 		return
-	case tcell.KeyLeft:
-		g.moveLeft()
-	case tcell.KeyRight:
-		g.moveRight()
-	case tcell.KeyDown:
-		g.moveDown()
-	case tcell.KeyUp:
-		g.rotate()
+	}
+
+	if g.awaitingName {
+		g.handleNameInput(ev)
+		return
+	}
+
+	if g.gameOver {
+		return
+	}
+
+	if ev.Rune() == 'p' || ev.Rune() == 'P' {
+		g.togglePause()
+		return
+	}
+
+	if g.paused {
+		return
+	}
+
+	// control the piece movements using keyboard
+	if action, ok := replayActionForKey(ev); ok {
+		g.applyReplayAction(action)
+		g.recordAction(action)
+		return
 	}
 
 	if ev.Rune() == 'q' || ev.Rune() == 'Q' {
@@ -107,14 +230,49 @@ func (g *Game) handleKey(ev *tcell.EventKey) {
 	}
 }
 
+// recordAction appends action to the game's replay log, if one is being
+// recorded, timestamped at the current tick.
+func (g *Game) recordAction(action replayAction) {
+	if g.recorder == nil {
+		return
+	}
+	g.lock.Lock()
+	tick := g.tickCount
+	g.lock.Unlock()
+	if err := g.recorder.record(tick, action); err != nil {
+		log.Printf("failed to record replay event: %v", err)
+	}
+}
+
+// handleNameInput handles key events while prompting for the player's name
+// on a qualifying game-over score.
+func (g *Game) handleNameInput(ev *tcell.EventKey) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		g.submitScore()
+		return
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(g.nameEntry) > 0 {
+			g.nameEntry = g.nameEntry[:len(g.nameEntry)-1]
+		}
+	case tcell.KeyRune:
+		if len(g.nameEntry) < 10 {
+			g.nameEntry += string(ev.Rune())
+		}
+	}
+	g.tick()
+}
+
 // moveShape moves the current game piece to a new position on the board
 func (g *Game) moveShape() {
 	// remove the old shape from the board
 	oldShape := shapes[g.piece.PreviousShape.shape][g.piece.PreviousShape.pos]
-	g.fixPiece(oldShape, g.piece.Position.oldX, g.piece.Position.oldY, 0)
+	g.fixPiece(oldShape, g.piece.Position.oldX, g.piece.Position.oldY, EmptyState, tcell.ColorDefault)
 	// place the new shape on the board
 	current := shapes[g.piece.Shape.shape][g.piece.Shape.pos]
-	g.fixPiece(current, g.piece.Position.x, g.piece.Position.y, g.piece.Shape.state)
+	g.fixPiece(current, g.piece.Position.x, g.piece.Position.y, g.piece.Shape.state, pieceColors[g.piece.Shape.shape])
 	// update the piece position
 	g.piece.Position.oldX = g.piece.Position.x
 	g.piece.Position.oldY = g.piece.Position.y
@@ -123,35 +281,73 @@ func (g *Game) moveShape() {
 }
 
 // drawBoard draws the game board on the screen. It sets the color of
-// the blocks depending on whether they are part of a shape or just the background
+// the blocks depending on whether they are part of a shape, the ghost
+// piece's landing outline, or just the background
 func (g *Game) drawBoard() {
-	shapeColor := tcell.StyleDefault.Foreground(tcell.ColorBlue)
+	ghostColor := tcell.StyleDefault.Foreground(tcell.ColorGray)
 	bg := tcell.StyleDefault.Foreground(tcell.ColorLightCyan)
+	flashWhite := (g.fadeFrame/3)%2 == 0
+
+	display := g.board
+	if !g.gameOver {
+		g.paintGhost(&display)
+	}
 
 	for y := 0; y < gameGridHeight; y++ {
 		for x := 0; x < gameGridWidth; x++ {
-			g.screen.SetContent(x, y, '#', nil, bg)
+			cell := display[y][x]
+			switch cell.state {
+			case EmptyState:
+				g.screen.SetContent(x, y, '#', nil, bg)
+			case GhostState:
+				g.screen.SetContent(x, y, '+', nil, ghostColor)
+			case FadingState:
+				color := cell.color
+				if flashWhite {
+					color = tcell.ColorWhite
+				}
+				g.screen.SetContent(x, y, '#', nil, tcell.StyleDefault.Foreground(color))
+			default:
+				g.screen.SetContent(x, y, '#', nil, tcell.StyleDefault.Foreground(cell.color))
+			}
 		}
 	}
+}
 
-	for y := 0; y < gameGridHeight; y++ {
-		for x := 0; x < gameGridWidth; x++ {
-			if g.board[y][x] != 0 {
-				g.screen.SetContent(x, y, '#', nil, shapeColor)
+// paintGhost marks display with the current piece's projected landing
+// position, without disturbing the real board or any occupied cell
+func (g *Game) paintGhost(display *Board) {
+	ghostY := g.ghostDropY()
+	shape := shapes[g.piece.Shape.shape][g.piece.Shape.pos]
+	for i := 0; i < 16; i++ {
+		if shape&(1<<uint(15-i)) != 0 {
+			xx, yy := g.piece.Position.x+i%4, ghostY+i/4
+			if isWithinBoard(xx, yy) && display[yy][xx].state == EmptyState {
+				display[yy][xx] = Cell{state: GhostState}
 			}
 		}
 	}
 }
 
+// ghostDropY returns the y position the current piece would land at if
+// hard-dropped from where it is now
+func (g *Game) ghostDropY() int {
+	y := g.piece.Position.y
+	for !g.hasCollision(g.piece.Position.x, y+1, g.piece.Shape.pos) {
+		y++
+	}
+	return y
+}
+
 // fixPiece updates the board with the given shape at the given position
-// with the specified state. It helps in moving a shape around the board
-func (g *Game) fixPiece(shape, x, y int, val ShapeState) {
+// with the specified state and color. It helps in moving a shape around the board
+func (g *Game) fixPiece(shape, x, y int, val ShapeState, color tcell.Color) {
 	for i := 0; i < 16; i++ {
 		if shape&(1<<uint(15-i)) != 0 {
 			xx := x + i%4
 			yy := y + i/4
 			if xx >= 0 && xx < gameGridWidth && yy >= 0 && yy < gameGridHeight {
-				g.board[yy][xx] = val
+				g.board[yy][xx] = Cell{state: val, color: color}
 			}
 		}
 	}
@@ -164,7 +360,11 @@ func (g *Game) hasCollision(x, y, pos int) bool {
 	for i := 0; i < 16; i++ {
 		if shape&(1<<uint(15-i)) != 0 {
 			xx, yy := x+i%4, y+i/4
-			if !isWithinBoard(xx, yy) || g.board[yy][xx] == FixedState {
+			if !isWithinBoard(xx, yy) {
+				return true
+			}
+			state := g.board[yy][xx].state
+			if state == FixedState || state == FadingState {
 				return true
 			}
 		}
@@ -172,29 +372,65 @@ func (g *Game) hasCollision(x, y, pos int) bool {
 	return false
 }
 
-// removeFullLines checks the board for any full lines and removes them
+// removeFullLines checks the board for any newly full lines and marks them
+// as fading. The fade-out animation collapses them and scores the clear once
+// it completes; see advanceFade
 func (g *Game) removeFullLines() {
+	if len(g.fadingRows) > 0 {
+		return
+	}
+
+	var fading []int
 	for y := 0; y < gameGridHeight; y++ {
 		full := true
 		for x := 0; x < gameGridWidth; x++ {
-			if g.board[y][x] != FixedState {
+			if g.board[y][x].state != FixedState {
 				full = false
 				break
 			}
 		}
 		if full {
-			// Remove the line and shift lines above down
-			for yy := y; yy > 0; yy-- {
-				for xx := 0; xx < gameGridWidth; xx++ {
-					g.board[yy][xx] = g.board[yy-1][xx]
-				}
-			}
-			// Clear the top line
+			fading = append(fading, y)
 			for x := 0; x < gameGridWidth; x++ {
-				g.board[0][x] = EmptyState
+				g.board[y][x].state = FadingState
+			}
+		}
+	}
+	g.fadingRows = fading
+	g.fadeFrame = 0
+}
+
+// advanceFade progresses the line-clear fade animation by one render frame,
+// collapsing and scoring the fading rows once fadeFrames have elapsed
+func (g *Game) advanceFade() {
+	if len(g.fadingRows) == 0 {
+		return
+	}
+	g.fadeFrame++
+	if g.fadeFrame < fadeFrames {
+		return
+	}
+	g.collapseFadingRows()
+}
+
+// collapseFadingRows removes the fading rows from the board, shifting the
+// rows above them down, and scores the clear
+func (g *Game) collapseFadingRows() {
+	for _, y := range g.fadingRows {
+		// Remove the line and shift lines above down
+		for yy := y; yy > 0; yy-- {
+			for xx := 0; xx < gameGridWidth; xx++ {
+				g.board[yy][xx] = g.board[yy-1][xx]
 			}
 		}
+		// Clear the top line
+		for x := 0; x < gameGridWidth; x++ {
+			g.board[0][x] = Cell{state: EmptyState}
+		}
 	}
+	g.scoreLines(len(g.fadingRows))
+	g.fadingRows = nil
+	g.fadeFrame = 0
 }
 
 // moveLeft attempts to move the current piece to the left
@@ -204,6 +440,7 @@ func (g *Game) moveLeft() {
 	d := findFirstNonEmptyColumn(shapes[g.piece.Shape.shape][g.piece.Shape.pos])
 	if g.piece.Position.x+d-1 >= 0 && !g.hasCollision(g.piece.Position.x-1, g.piece.Position.y, g.piece.Shape.pos) {
 		g.piece.Position.x--
+		g.lockDelayStart = time.Time{}
 	}
 	g.tick()
 }
@@ -216,70 +453,232 @@ func (g *Game) moveRight() {
 	x := g.piece.Position.x + findFirstNonEmptyColumn(shapes[g.piece.Shape.shape][g.piece.Shape.pos])
 	if x+width < gameGridWidth && !g.hasCollision(g.piece.Position.x+1, g.piece.Position.y, g.piece.Shape.pos) {
 		g.piece.Position.x++
+		g.lockDelayStart = time.Time{}
 	}
 	g.tick()
 }
 
-// moveDown attempts to move the current piece downwards.
-// If the piece cannot move any further, it is set to fixed and a new piece is created
+// moveDown attempts to move the current piece downwards under gravity.
+// If the piece cannot move any further, it is fixed in place, after a short
+// lock delay, and a new piece is created
 func (g *Game) moveDown() {
+	g.moveDownWithScore(false)
+}
+
+// tickGravity runs one gravity-ticker step for runGameLoop: it advances the
+// piece downward unless the game is paused or over, and reports the current
+// level so the caller can detect a level-up and resize its ticker. All reads
+// of shared state happen under g.lock, since this runs concurrently with the
+// input-handling goroutine.
+func (g *Game) tickGravity() int {
+	g.lock.Lock()
+	gameOver := g.gameOver
+	paused := g.paused
+	level := g.level
+	if !gameOver && !paused {
+		g.tickCount++
+	}
+	g.lock.Unlock()
+
+	if !gameOver && !paused {
+		g.moveDown()
+	}
+	return level
+}
+
+// softDrop moves the current piece down one cell in response to the player
+// holding Down, awarding the soft-drop bonus for the cell gained
+func (g *Game) softDrop() {
+	g.moveDownWithScore(true)
+}
+
+// moveDownWithScore implements the shared logic behind moveDown and softDrop.
+// When manual is true and the piece actually descends a cell, the soft-drop
+// bonus is awarded
+func (g *Game) moveDownWithScore(manual bool) {
 	g.lock.Lock()
 	defer g.lock.Unlock()
-	if g.hasCollision(g.piece.Position.x, g.piece.Position.y+1, g.piece.Shape.pos) {
-		if g.piece.Position.y == -getTopOffset(shapes[g.piece.Shape.shape][g.piece.Shape.pos]) {
-			g.cancel()
-			return
-		}
-		g.piece.Shape.state = FixedState
-	} else {
+
+	if !g.hasCollision(g.piece.Position.x, g.piece.Position.y+1, g.piece.Shape.pos) {
 		g.piece.Shape.state = FallingState
 		g.piece.Position.y++
+		g.lockDelayStart = time.Time{}
+		if manual {
+			g.addDropScore(1, softDropPointsPerCell)
+		}
+		g.tick()
+		return
 	}
-	if g.piece.Shape.state == FixedState {
+
+	// The piece is resting on the stack or floor; give the player a brief
+	// window to slide or spin it before it locks in place.
+	if !g.tryLockDelay() {
 		g.tick()
-		g.newPiece()
+		return
+	}
+
+	if g.piece.Position.y == -getTopOffset(shapes[g.piece.Shape.shape][g.piece.Shape.pos]) {
+		g.endGame()
+		return
 	}
+
+	g.piece.Shape.state = FixedState
+	g.tick()
+	g.spawnFromQueue()
 	g.tick()
 }
 
-// rotate attempts to rotate the current piece.
-// If rotation would result in a collision, the piece is left in its current orientation
+// hardDrop instantly drops the current piece to the lowest non-colliding
+// position, locks it immediately, and awards the hard-drop bonus for every
+// cell it fell
+func (g *Game) hardDrop() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	startY := g.piece.Position.y
+	dropY := startY
+	for !g.hasCollision(g.piece.Position.x, dropY+1, g.piece.Shape.pos) {
+		dropY++
+	}
+
+	if dropY == startY && startY == -getTopOffset(shapes[g.piece.Shape.shape][g.piece.Shape.pos]) {
+		g.endGame()
+		return
+	}
+
+	g.piece.Position.y = dropY
+	g.piece.Shape.state = FixedState
+	g.addDropScore(dropY-startY, hardDropPointsPerCell)
+	g.tick()
+	g.spawnFromQueue()
+	g.tick()
+}
+
+// tryLockDelay tracks how long the piece has been resting without locking.
+// It starts the lock-delay timer on first call and reports whether lockDelay
+// has since elapsed, at which point the timer is reset for the next piece
+func (g *Game) tryLockDelay() bool {
+	if g.lockDelayStart.IsZero() {
+		g.lockDelayStart = time.Now()
+		return false
+	}
+	if time.Since(g.lockDelayStart) >= lockDelay {
+		g.lockDelayStart = time.Time{}
+		return true
+	}
+	return false
+}
+
+// togglePause halts and resumes gravity and player input, other than unpausing itself
+func (g *Game) togglePause() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.paused = !g.paused
+	g.tick()
+}
+
+// attemptRotation tries to turn the current piece to the next SRS rotation
+// state, testing the raw rotated position first and then the remaining
+// wall-kick offsets from the SRS tables, in order. It applies and reports the
+// first offset that produces a collision-free placement, recording it in
+// lastKick, or leaves the piece untouched and reports false if none succeed.
+func (g *Game) attemptRotation() bool {
+	from := g.piece.Shape.pos
+	to := (from + 1) % 4
+	for _, kick := range kickOffsets(g.piece.Shape.shape, from, to) {
+		x := g.piece.Position.x + kick.dx
+		y := g.piece.Position.y + kick.dy
+		if !g.hasCollision(x, y, to) {
+			g.piece.Shape.pos = to
+			g.piece.Position.x = x
+			g.piece.Position.y = y
+			g.lastKick = kick
+			g.moveShape()
+			g.lockDelayStart = time.Time{}
+			return true
+		}
+	}
+	return false
+}
+
+// rotate attempts to rotate the current piece using the Super Rotation
+// System. If rotation would result in a collision, the piece is left in its
+// current orientation
 func (g *Game) rotate() {
 	g.lock.Lock()
 	defer g.lock.Unlock()
-	startY := g.piece.Position.y + getTopOffset(shapes[g.piece.Shape.shape][g.piece.Shape.pos])
-	startX := g.piece.Position.x + findFirstNonEmptyColumn(shapes[g.piece.Shape.shape][g.piece.Shape.pos])
-	// move shape to the next pos
-	g.piece.Shape.pos = (g.piece.Shape.pos + 1) % 4
-	// find the next coords for the new shape
-	g.piece.Position.x = startX - findFirstNonEmptyColumn(shapes[g.piece.Shape.shape][g.piece.Shape.pos])
-	g.piece.Position.y = startY - getTopOffset(shapes[g.piece.Shape.shape][g.piece.Shape.pos])
-
-	for g.piece.Position.x+findFirstNonEmptyColumn(shapes[g.piece.Shape.shape][g.piece.Shape.pos]) >= 0 &&
-		g.hasCollision(g.piece.Position.x, g.piece.Position.y, g.piece.Shape.pos) {
-		g.piece.Position.x--
+	g.attemptRotation()
+	g.tick()
+}
+
+// endGame stops piece movement and either prompts for a name, if the final
+// score qualifies for the high-score table, or leaves the game-over screen up.
+func (g *Game) endGame() {
+	g.gameOver = true
+	if qualifies(g.scores, g.score) {
+		g.awaitingName = true
 	}
+	g.tick()
+}
 
-	if g.hasCollision(g.piece.Position.x, g.piece.Position.y, g.piece.Shape.pos) {
-		g.piece.Shape.pos = g.piece.PreviousShape.pos
-		g.piece.Position.x = g.piece.Position.oldX
-		g.piece.Position.y = g.piece.Position.oldY
-	} else {
-		g.moveShape()
+// submitScore records the entered name against the just-finished game and
+// persists the updated high-score table.
+func (g *Game) submitScore() {
+	name := strings.TrimSpace(g.nameEntry)
+	if name == "" {
+		name = "Player"
+	}
+	entry := ScoreEntry{
+		Name:  name,
+		Score: g.score,
+		Lines: g.lines,
+		Level: g.level,
+		Date:  time.Now(),
 	}
+	g.scores = insertScore(g.scores, entry)
+	if err := saveScores(g.scores); err != nil {
+		log.Printf("failed to save high scores: %v", err)
+	}
+	g.awaitingName = false
 	g.tick()
 }
 
-// tick updates the game screen to reflect the current state of the game
-func (g *Game) tick() {
+// update applies the current piece's position to the board and checks for
+// newly completed lines
+func (g *Game) update() {
+	if !g.gameOver {
+		g.moveShape()
+		g.removeFullLines()
+	}
+}
+
+// render redraws the game screen to reflect the current state of the game.
+// It is a no-op without a screen, which lets replay playback and tests drive
+// the game headlessly.
+func (g *Game) render() {
+	if g.screen == nil {
+		return
+	}
 	g.screen.Clear()
 	g.drawMenu()
-	g.moveShape()
-	g.removeFullLines()
 	g.drawBoard()
+	if g.gameOver {
+		g.drawGameOver()
+	} else if g.paused {
+		g.drawPaused()
+	}
 	g.screen.Show()
 }
 
+// tick applies the pending move to the board and immediately redraws the
+// screen. The render loop in runGameLoop redraws independently at a fixed
+// rate but never applies moves, so every move, by gravity or by the player,
+// reaches the board through tick
+func (g *Game) tick() {
+	g.update()
+	g.render()
+}
+
 // runGameLoop runs the main game loop. It listens for user input,
 // updates the game state, and handles system signals for graceful shutdown
 func (g *Game) runGameLoop(signalChan chan os.Signal) {
@@ -293,9 +692,15 @@ func (g *Game) runGameLoop(signalChan chan os.Signal) {
 	}()
 
 	var wg sync.WaitGroup
-	ticker := time.NewTicker(time.Second)
+	level := g.level
+	ticker := time.NewTicker(gravityInterval(level))
 	defer ticker.Stop()
 
+	// renderTicker redraws the screen and advances the line-clear fade at a
+	// fixed rate, independent of gravity and player input.
+	renderTicker := time.NewTicker(renderInterval)
+	defer renderTicker.Stop()
+
 	go func() {
 		// This goroutine will listen for a system signal and cancel the context
 		// when either SIGINT (Ctrl+C) or SIGTERM is received.
@@ -325,10 +730,24 @@ func (g *Game) runGameLoop(signalChan chan os.Signal) {
 		case <-g.ctx.Done():
 			// This will happen when the context is cancelled, i.e., the system signal is received.
 			log.Println("Shutting down gracefully...")
+			if g.recorder != nil {
+				if err := g.recorder.Close(); err != nil {
+					log.Printf("failed to close replay log: %v", err)
+				}
+			}
 			wg.Done()
 			os.Exit(0)
 		case <-ticker.C:
-			g.moveDown()
+			if newLevel := g.tickGravity(); newLevel != level {
+				level = newLevel
+				ticker.Stop()
+				ticker = time.NewTicker(gravityInterval(level))
+			}
+		case <-renderTicker.C:
+			g.lock.Lock()
+			g.advanceFade()
+			g.render()
+			g.lock.Unlock()
 		}
 	}
 	wg.Wait()
@@ -350,6 +769,11 @@ func initializeScreen() (tcell.Screen, error) {
 }
 
 func main() {
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed for the random number generator; recorded into any replay log")
+	replayPath := flag.String("replay", "", "play back a previously recorded replay log instead of live input")
+	recordPath := flag.String("record", "", "record this game's input to a replay log at the given path")
+	flag.Parse()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -365,6 +789,29 @@ func main() {
 	screen.EnableMouse()
 	screen.Clear()
 
+	if *replayPath != "" {
+		header, events, err := loadReplay(*replayPath)
+		if err != nil {
+			log.Fatalf("failed to load replay %s: %v", *replayPath, err)
+		}
+		rand.Seed(header.Seed)
+		g := newGame(screen, ctx, cancel)
+		g.runReplay(events)
+		g.render()
+		return
+	}
+
+	rand.Seed(*seed)
 	g := newGame(screen, ctx, cancel)
+
+	if *recordPath != "" {
+		recorder, err := newReplayRecorder(*recordPath, *seed)
+		if err != nil {
+			log.Fatalf("failed to start replay recording at %s: %v", *recordPath, err)
+		}
+		defer recorder.Close()
+		g.recorder = recorder
+	}
+
 	g.runGameLoop(signalChan)
 }
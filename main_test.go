@@ -0,0 +1,64 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestTickGravityConcurrentWithTogglePause drives the actual gravity-ticker
+// code path used by runGameLoop (tickGravity, which reads gameOver/paused/
+// level) concurrently with togglePause, the input-handling path that mutates
+// them. Run with `go test -race` to catch the unguarded read this guards
+// against; TestTogglePauseConcurrentSafe alone does not exercise it, since
+// both sides there already take g.lock.
+func TestTickGravityConcurrentWithTogglePause(t *testing.T) {
+	g := newTestGame(shapeT, 3, 5)
+	g.level = 1
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			g.tickGravity()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			g.togglePause()
+		}
+	}()
+
+	wg.Wait()
+}
+
+// TestTogglePauseConcurrentSafe exercises togglePause alongside a concurrent,
+// lock-guarded reader of g.paused, documenting that both the mutation and
+// the read must go through g.lock.
+func TestTogglePauseConcurrentSafe(t *testing.T) {
+	g := &Game{held: noHeldPiece, level: 1, gameOver: true}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			g.togglePause()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			g.lock.Lock()
+			_ = g.paused
+			g.lock.Unlock()
+		}
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gdamore/tcell"
+)
+
+// replayFormatVersion is written into every replay log's header so a future
+// version of the game can detect an incompatible log.
+const replayFormatVersion = 1
+
+// replayAction identifies one of the player actions that affect deterministic
+// gameplay and are therefore worth recording. Pause, quit, and the
+// name-entry keys never change the board, so they aren't replayed.
+type replayAction byte
+
+const (
+	actionLeft replayAction = iota + 1
+	actionRight
+	actionDown
+	actionUp
+	actionHold
+	actionHardDrop
+)
+
+// replayActionForKey maps a key event to the replay action it represents, if
+// any. It mirrors the dispatch in handleKey.
+func replayActionForKey(ev *tcell.EventKey) (replayAction, bool) {
+	switch ev.Key() {
+	case tcell.KeyLeft:
+		return actionLeft, true
+	case tcell.KeyRight:
+		return actionRight, true
+	case tcell.KeyDown:
+		return actionDown, true
+	case tcell.KeyUp:
+		return actionUp, true
+	}
+	switch ev.Rune() {
+	case 'c', 'C':
+		return actionHold, true
+	case ' ':
+		return actionHardDrop, true
+	}
+	return 0, false
+}
+
+// replayHeader is the JSON preamble of a replay log. It is followed by a
+// newline and then the binary event stream.
+type replayHeader struct {
+	Version int   `json:"version"`
+	Seed    int64 `json:"seed"`
+	Width   int   `json:"width"`
+	Height  int   `json:"height"`
+}
+
+// replayEvent is one recorded player action. tick counts gravity steps run
+// since the game started; ticks spent paused are not counted.
+type replayEvent struct {
+	tick   uint64
+	action replayAction
+}
+
+// replayRecorder appends player actions to a replay log as they happen. The
+// binary stream is a sequence of (tick_delta_uvarint, action_byte) records.
+type replayRecorder struct {
+	file     *os.File
+	w        *bufio.Writer
+	lastTick uint64
+}
+
+// newReplayRecorder creates the replay log at path and writes its header.
+func newReplayRecorder(path string, seed int64) (*replayRecorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	header := replayHeader{
+		Version: replayFormatVersion,
+		Seed:    seed,
+		Width:   gameGridWidth,
+		Height:  gameGridHeight,
+	}
+	if err := json.NewEncoder(f).Encode(header); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &replayRecorder{file: f, w: bufio.NewWriter(f)}, nil
+}
+
+// record appends action at tick to the replay log.
+func (r *replayRecorder) record(tick uint64, action replayAction) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], tick-r.lastTick)
+	if _, err := r.w.Write(buf[:n]); err != nil {
+		return err
+	}
+	if err := r.w.WriteByte(byte(action)); err != nil {
+		return err
+	}
+	r.lastTick = tick
+	return nil
+}
+
+// Close flushes and closes the underlying replay log file.
+func (r *replayRecorder) Close() error {
+	if err := r.w.Flush(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+// loadReplay reads a replay log's header and decodes its full event stream.
+func loadReplay(path string) (replayHeader, []replayEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return replayHeader{}, nil, err
+	}
+
+	nl := bytes.IndexByte(data, '\n')
+	if nl < 0 {
+		return replayHeader{}, nil, fmt.Errorf("replay %s: missing header", path)
+	}
+	var header replayHeader
+	if err := json.Unmarshal(data[:nl], &header); err != nil {
+		return replayHeader{}, nil, fmt.Errorf("replay %s: %w", path, err)
+	}
+	if header.Version != replayFormatVersion {
+		return replayHeader{}, nil, fmt.Errorf("replay %s: unsupported version %d", path, header.Version)
+	}
+
+	var events []replayEvent
+	r := bytes.NewReader(data[nl+1:])
+	var tick uint64
+	for {
+		delta, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return replayHeader{}, nil, fmt.Errorf("replay %s: %w", path, err)
+		}
+		action, err := r.ReadByte()
+		if err != nil {
+			return replayHeader{}, nil, fmt.Errorf("replay %s: truncated record", path)
+		}
+		tick += delta
+		events = append(events, replayEvent{tick: tick, action: replayAction(action)})
+	}
+	return header, events, nil
+}
+
+// applyReplayAction dispatches a recorded action to the same handler a live
+// key press would use.
+func (g *Game) applyReplayAction(action replayAction) {
+	switch action {
+	case actionLeft:
+		g.moveLeft()
+	case actionRight:
+		g.moveRight()
+	case actionDown:
+		g.softDrop()
+	case actionUp:
+		g.rotate()
+	case actionHold:
+		g.hold()
+	case actionHardDrop:
+		g.hardDrop()
+	}
+}
+
+// runReplay drives the game from a pre-recorded sequence of events instead
+// of live input, advancing the tick clock in simulated rather than real
+// time so playback is deterministic and instantaneous. It stops once every
+// event has been applied and its tick's gravity step has run, or once the
+// game ends.
+func (g *Game) runReplay(events []replayEvent) {
+	var last uint64
+	if len(events) > 0 {
+		last = events[len(events)-1].tick
+	}
+
+	idx := 0
+	for tick := uint64(0); tick <= last; tick++ {
+		for idx < len(events) && events[idx].tick == tick {
+			g.applyReplayAction(events[idx].action)
+			idx++
+		}
+		if g.gameOver {
+			return
+		}
+		// In live play the lock-delay timer reads the wall clock and the
+		// line-clear fade advances in the render loop; here both advance by
+		// one gravity interval of simulated time per tick instead.
+		interval := gravityInterval(g.level)
+		if !g.lockDelayStart.IsZero() {
+			g.lockDelayStart = g.lockDelayStart.Add(-interval)
+		}
+		for f := 0; f < int(interval/renderInterval); f++ {
+			g.advanceFade()
+		}
+		if !g.paused {
+			g.moveDown()
+		}
+		if g.gameOver {
+			return
+		}
+		g.tickCount = tick + 1
+	}
+}
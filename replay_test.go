@@ -0,0 +1,61 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReplayRecordAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.replay")
+
+	recorder, err := newReplayRecorder(path, 12345)
+	if err != nil {
+		t.Fatalf("newReplayRecorder: %v", err)
+	}
+	want := []replayEvent{
+		{tick: 0, action: actionRight},
+		{tick: 1, action: actionRight},
+		{tick: 2, action: actionHardDrop},
+	}
+	for _, ev := range want {
+		if err := recorder.record(ev.tick, ev.action); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	header, events, err := loadReplay(path)
+	if err != nil {
+		t.Fatalf("loadReplay: %v", err)
+	}
+	if header.Seed != 12345 || header.Width != gameGridWidth || header.Height != gameGridHeight {
+		t.Fatalf("header = %+v, want seed 12345 and board %dx%d", header, gameGridWidth, gameGridHeight)
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("events = %v, want %v", events, want)
+	}
+}
+
+// TestRunReplayReproducesFinalBoard replays a canned sequence of moves
+// against a freshly spawned O piece on an empty board and checks that it
+// locks exactly where two rights and a hard drop should put it.
+func TestRunReplayReproducesFinalBoard(t *testing.T) {
+	g := &Game{held: noHeldPiece, level: 1}
+	g.setPiece(shapeO)
+
+	events := []replayEvent{
+		{tick: 0, action: actionRight},
+		{tick: 1, action: actionRight},
+		{tick: 2, action: actionHardDrop},
+	}
+	g.runReplay(events)
+
+	for _, cell := range []struct{ x, y int }{{2, 18}, {3, 18}, {2, 19}, {3, 19}} {
+		if g.board[cell.y][cell.x].state != FixedState {
+			t.Fatalf("board[%d][%d].state = %v, want FixedState", cell.y, cell.x, g.board[cell.y][cell.x].state)
+		}
+	}
+}
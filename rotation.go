@@ -0,0 +1,52 @@
+package main
+
+// Offset is a (dx, dy) wall-kick test offset, in board coordinates where y
+// grows downward.
+type Offset struct {
+	dx, dy int
+}
+
+// jlstzKicks holds the five wall-kick test offsets shared by the J, L, S, T,
+// and Z pieces for each rotation transition, keyed by {fromPos, toPos}. The
+// first offset of every entry is {0, 0}, the raw rotated position.
+var jlstzKicks = map[[2]int][]Offset{
+	{rotSpawn, rotR}: {{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}},
+	{rotR, rotSpawn}: {{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}},
+	{rotR, rot2}:     {{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}},
+	{rot2, rotR}:     {{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}},
+	{rot2, rotL}:     {{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}},
+	{rotL, rot2}:     {{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}},
+	{rotL, rotSpawn}: {{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}},
+	{rotSpawn, rotL}: {{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}},
+}
+
+// iKicks holds the five wall-kick test offsets for the I piece, which kicks
+// by different distances than the other non-O pieces.
+var iKicks = map[[2]int][]Offset{
+	{rotSpawn, rotR}: {{0, 0}, {-2, 0}, {1, 0}, {-2, 1}, {1, -2}},
+	{rotR, rotSpawn}: {{0, 0}, {2, 0}, {-1, 0}, {2, -1}, {-1, 2}},
+	{rotR, rot2}:     {{0, 0}, {-1, 0}, {2, 0}, {-1, -2}, {2, 1}},
+	{rot2, rotR}:     {{0, 0}, {1, 0}, {-2, 0}, {1, 2}, {-2, -1}},
+	{rot2, rotL}:     {{0, 0}, {2, 0}, {-1, 0}, {2, -1}, {-1, 2}},
+	{rotL, rot2}:     {{0, 0}, {-2, 0}, {1, 0}, {-2, 1}, {1, -2}},
+	{rotL, rotSpawn}: {{0, 0}, {1, 0}, {-2, 0}, {1, 2}, {-2, -1}},
+	{rotSpawn, rotL}: {{0, 0}, {-1, 0}, {2, 0}, {-1, -2}, {2, 1}},
+}
+
+// kickOffsets returns the ordered wall-kick test offsets for rotating shape
+// from one SRS rotation state to another. The O piece has a single cell of
+// symmetry in every state and never kicks; the I piece uses its own table;
+// every other piece shares jlstzKicks.
+func kickOffsets(shape, from, to int) []Offset {
+	if shape == shapeO {
+		return []Offset{{0, 0}}
+	}
+	table := jlstzKicks
+	if shape == shapeI {
+		table = iKicks
+	}
+	if offsets, ok := table[[2]int{from, to}]; ok {
+		return offsets
+	}
+	return []Offset{{0, 0}}
+}
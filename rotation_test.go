@@ -0,0 +1,97 @@
+package main
+
+import "testing"
+
+// newTestGame builds a minimal Game around shape at rotSpawn, placed at
+// (x, y), with an otherwise empty board. It leaves g.screen nil, which render
+// skips, so it is safe to use without a real tcell screen.
+func newTestGame(shape, x, y int) *Game {
+	g := &Game{held: noHeldPiece, level: 1}
+	g.piece = &Piece{
+		Shape:         Shape{shape: shape, pos: rotSpawn, state: FallingState},
+		Position:      Position{x: x, y: y, oldX: x, oldY: y},
+		PreviousShape: Shape{shape: shape, pos: rotSpawn},
+	}
+	g.moveShape()
+	return g
+}
+
+func TestKickOffsetsStartWithRawPosition(t *testing.T) {
+	for shape := 0; shape < len(shapes); shape++ {
+		offsets := kickOffsets(shape, rotSpawn, rotR)
+		if offsets[0] != (Offset{}) {
+			t.Fatalf("shape %d: first offset = %v, want the raw rotated position {0,0}", shape, offsets[0])
+		}
+	}
+}
+
+func TestKickOffsetsOPieceNeverKicks(t *testing.T) {
+	offsets := kickOffsets(shapeO, rotSpawn, rotR)
+	if len(offsets) != 1 || offsets[0] != (Offset{}) {
+		t.Fatalf("kickOffsets(O) = %v, want only the raw position", offsets)
+	}
+}
+
+// TestAttemptRotationJLSTZWallKick obstructs the raw rotated position of a T
+// piece so that only the JLSTZ table's {-1, 0} kick clears it.
+func TestAttemptRotationJLSTZWallKick(t *testing.T) {
+	g := newTestGame(shapeT, 3, 5)
+	g.board[6][5] = Cell{state: FixedState, color: pieceColors[shapeT]} // blocks the raw rotated position
+
+	if !g.attemptRotation() {
+		t.Fatal("expected rotation to succeed via wall kick")
+	}
+	if g.piece.Shape.pos != rotR {
+		t.Fatalf("pos = %d, want %d", g.piece.Shape.pos, rotR)
+	}
+	if g.piece.Position.x != 2 {
+		t.Fatalf("x = %d, want 2 (kicked by -1)", g.piece.Position.x)
+	}
+	if g.lastKick != (Offset{dx: -1, dy: 0}) {
+		t.Fatalf("lastKick = %v, want {-1, 0}", g.lastKick)
+	}
+}
+
+// TestAttemptRotationIPieceOwnTable obstructs the raw rotated position of an
+// I piece so that only its own table's {-2, 0} kick clears it; the JLSTZ
+// table has no such offset, so this only passes if the I-piece table is used.
+func TestAttemptRotationIPieceOwnTable(t *testing.T) {
+	g := newTestGame(shapeI, 6, 5)
+	g.board[6][8] = Cell{state: FixedState, color: pieceColors[shapeI]} // blocks the raw rotated position
+
+	if !g.attemptRotation() {
+		t.Fatal("expected the I piece to kick clear of the obstruction")
+	}
+	if g.piece.Shape.pos != rotR {
+		t.Fatalf("pos = %d, want %d", g.piece.Shape.pos, rotR)
+	}
+	if g.piece.Position.x != 4 {
+		t.Fatalf("x = %d, want 4 (kicked by -2)", g.piece.Position.x)
+	}
+	if g.lastKick != (Offset{dx: -2, dy: 0}) {
+		t.Fatalf("lastKick = %v, want {-2, 0}", g.lastKick)
+	}
+}
+
+// TestAttemptRotationRejectedWhenEntombed fills the entire board with
+// obstacles around a J piece, so that the raw position and every wall-kick
+// candidate collide; the rotation must be rejected and the piece left
+// exactly where it was.
+func TestAttemptRotationRejectedWhenEntombed(t *testing.T) {
+	g := newTestGame(shapeJ, 4, 5)
+	for y := 0; y < gameGridHeight; y++ {
+		for x := 0; x < gameGridWidth; x++ {
+			g.board[y][x] = Cell{state: FixedState, color: pieceColors[shapeJ]}
+		}
+	}
+
+	beforePos := g.piece.Shape.pos
+	beforeX, beforeY := g.piece.Position.x, g.piece.Position.y
+
+	if g.attemptRotation() {
+		t.Fatal("expected rotation to be rejected")
+	}
+	if g.piece.Shape.pos != beforePos || g.piece.Position.x != beforeX || g.piece.Position.y != beforeY {
+		t.Fatal("piece must be left untouched when rotation is rejected")
+	}
+}
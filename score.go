@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// maxScoreEntries is how many rows the persisted high-score table keeps.
+const maxScoreEntries = 10
+
+// linesPerLevel is how many cleared lines it takes to advance one level.
+const linesPerLevel = 10
+
+// softDropPointsPerCell and hardDropPointsPerCell are the per-cell bonuses
+// awarded for manually dropping a piece instead of waiting on gravity.
+const (
+	softDropPointsPerCell = 1
+	hardDropPointsPerCell = 2
+)
+
+// lineClearScores maps the number of lines cleared in one move to its base
+// point value, following the standard single/double/triple/tetris scale.
+var lineClearScores = map[int]int{
+	1: 100,
+	2: 300,
+	3: 500,
+	4: 800,
+}
+
+// ScoreEntry is one row of the persisted high-score table.
+type ScoreEntry struct {
+	Name  string    `json:"name"`
+	Score int       `json:"score"`
+	Lines int       `json:"lines"`
+	Level int       `json:"level"`
+	Date  time.Time `json:"date"`
+}
+
+// scoresFilePath returns the on-disk location of the high-score table.
+func scoresFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".tetris-golang", "scores.json"), nil
+}
+
+// loadScores reads the persisted high-score table, returning an empty table
+// if none has been saved yet.
+func loadScores() ([]ScoreEntry, error) {
+	path, err := scoresFilePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var scores []ScoreEntry
+	if err := json.Unmarshal(data, &scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// saveScores writes the high-score table to disk, creating its directory if needed.
+func saveScores(scores []ScoreEntry) error {
+	path, err := scoresFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(scores, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// qualifies reports whether score would earn a spot in the high-score table.
+func qualifies(scores []ScoreEntry, score int) bool {
+	if len(scores) < maxScoreEntries {
+		return true
+	}
+	for _, s := range scores {
+		if score > s.Score {
+			return true
+		}
+	}
+	return false
+}
+
+// insertScore inserts entry into scores, keeping the table sorted by score
+// descending and trimmed to maxScoreEntries.
+func insertScore(scores []ScoreEntry, entry ScoreEntry) []ScoreEntry {
+	scores = append(scores, entry)
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	if len(scores) > maxScoreEntries {
+		scores = scores[:maxScoreEntries]
+	}
+	return scores
+}
+
+// scoreLines awards points for clearing cleared lines at the current level,
+// then advances lines and level.
+func (g *Game) scoreLines(cleared int) {
+	if cleared == 0 {
+		return
+	}
+	g.score += lineClearScores[cleared] * g.level
+	g.lines += cleared
+	g.level = g.lines/linesPerLevel + 1
+}
+
+// addDropScore awards the per-cell bonus for a manual soft or hard drop.
+func (g *Game) addDropScore(cells, pointsPerCell int) {
+	g.score += cells * pointsPerCell
+}
@@ -1,5 +1,10 @@
 package main
 
+import (
+	"math"
+	"time"
+)
+
 func isWithinBoard(x, y int) bool {
 	if x >= 0 && x < gameGridWidth && y >= 0 && y < gameGridHeight {
 		return true
@@ -46,3 +51,10 @@ func findFirstNonEmptyColumn(shape int) int {
 	}
 	return columnIndex
 }
+
+// gravityInterval returns the tick interval for the given level. Gravity speeds
+// up as the level increases, following a standard Tetris-style curve.
+func gravityInterval(level int) time.Duration {
+	factor := math.Pow(0.8-float64(level-1)*0.007, float64(level-1))
+	return time.Duration(float64(time.Second) * factor)
+}
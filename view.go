@@ -10,14 +10,53 @@ func (g *Game) drawMenu() {
 	style := tcell.StyleDefault.Foreground(tcell.ColorWhite)
 	for y, item := range menu {
 		if strings.HasPrefix(item, "Level:") {
-			item = fmt.Sprintf(item, 1)
+			item = fmt.Sprintf(item, g.level)
 		} else if strings.HasPrefix(item, "Lines:") {
-			item = fmt.Sprintf(item, 1)
-		} else if strings.HasPrefix(item, "GAME OVER") {
-			item = ""
+			item = fmt.Sprintf(item, g.lines)
+		} else if strings.HasPrefix(item, "Score:") {
+			item = fmt.Sprintf(item, g.score)
 		}
 		g.print(gameGridWidth+10, y, item, style)
 	}
+
+	y := len(menu) + 1
+	holdName := "-"
+	if g.held != noHeldPiece {
+		holdName = pieceNames[g.held]
+	}
+	g.print(gameGridWidth+10, y, fmt.Sprintf("Hold:  %s", holdName), style)
+
+	y += 2
+	g.print(gameGridWidth+10, y, "Next:", style)
+	for _, shape := range g.queue {
+		y++
+		g.print(gameGridWidth+10, y, fmt.Sprintf("  %s", pieceNames[shape]), style)
+	}
+
+	y += 2
+	g.print(gameGridWidth+10, y, "High Scores:", style)
+	for i, s := range g.scores {
+		y++
+		g.print(gameGridWidth+10, y, fmt.Sprintf("%2d. %-10s %6d", i+1, s.Name, s.Score), style)
+	}
+}
+
+// drawPaused overlays a "PAUSED" banner on the board while the game is paused.
+func (g *Game) drawPaused() {
+	style := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	x, y := gameGridWidth/2-3, gameGridHeight/2-1
+	g.print(x, y, "PAUSED", style)
+}
+
+// drawGameOver overlays the game-over screen on the board, prompting for a
+// name when the final score qualifies for the high-score table.
+func (g *Game) drawGameOver() {
+	style := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	x, y := gameGridWidth/2-4, gameGridHeight/2-1
+	g.print(x, y, "GAME OVER", style)
+	if g.awaitingName {
+		g.print(x, y+2, fmt.Sprintf("Enter name: %s", g.nameEntry), style)
+	}
 }
 
 func (g *Game) print(x, y int, msg string, color tcell.Style) {